@@ -0,0 +1,35 @@
+package backoff
+
+import "time"
+
+// Stop indicates that no more retries should be made.
+const Stop time.Duration = -1
+
+// BackOff computes the delay before the next retry attempt.
+type BackOff interface {
+	// NextBackOff returns the duration to wait before the next attempt, or
+	// Stop if no more retries should be made.
+	NextBackOff() time.Duration
+
+	// Reset returns the BackOff to its initial state.
+	Reset()
+}
+
+// BackOffCloner is implemented by a BackOff that can produce an independent
+// copy of itself, so RetryAll can give each item its own mutable state.
+// Clone's second result is false if b wraps another BackOff that isn't
+// itself cloneable.
+type BackOffCloner interface {
+	BackOff
+	Clone() (BackOff, bool)
+}
+
+// cloneBackOff returns an independent copy of b via BackOffCloner, and ok is
+// false if b doesn't implement it, or wraps a BackOff that doesn't.
+func cloneBackOff(b BackOff) (clone BackOff, ok bool) {
+	c, ok := b.(BackOffCloner)
+	if !ok {
+		return nil, false
+	}
+	return c.Clone()
+}
@@ -0,0 +1,80 @@
+// Package backoffhttp provides a backoff.RetryPolicy for HTTP clients.
+package backoffhttp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gosharmot/backoff"
+)
+
+// ResponseError wraps a non-2xx *http.Response so DefaultHTTPRetryPolicy can
+// inspect its status code and headers.
+type ResponseError struct {
+	Response *http.Response
+}
+
+func (e *ResponseError) Error() string {
+	return "unexpected http status: " + e.Response.Status
+}
+
+// DefaultHTTPRetryPolicy is a backoff.RetryPolicy for operations that return
+// a *ResponseError on non-2xx responses.
+func DefaultHTTPRetryPolicy(_ context.Context, err error) backoff.Decision {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		return decideStatusCode(respErr.Response)
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		var opErr *net.OpError
+		if errors.As(urlErr.Err, &opErr) || urlErr.Timeout() {
+			return backoff.DecisionRetry
+		}
+		return backoff.DecisionStop
+	}
+
+	return backoff.DecisionRetry
+}
+
+func decideStatusCode(resp *http.Response) backoff.Decision {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return backoff.DecisionRetryAfter(d)
+		}
+		return backoff.DecisionRetry
+	}
+
+	switch {
+	case resp.StatusCode >= 500:
+		return backoff.DecisionRetry
+	case resp.StatusCode >= 400:
+		return backoff.DecisionStop
+	default:
+		return backoff.DecisionRetry
+	}
+}
+
+// retryAfter parses the Retry-After header, which is either a number of
+// seconds or an HTTP-date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,104 @@
+package backoffhttp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gosharmot/backoff"
+)
+
+func response(status int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Header: header}
+}
+
+func TestDefaultHTTPRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want backoff.Decision
+	}{
+		{
+			name: "429 without Retry-After retries on the normal schedule",
+			err:  &ResponseError{Response: response(http.StatusTooManyRequests, nil)},
+			want: backoff.DecisionRetry,
+		},
+		{
+			name: "429 with Retry-After in seconds honors it",
+			err: &ResponseError{Response: response(http.StatusTooManyRequests, http.Header{
+				"Retry-After": {"2"},
+			})},
+			want: backoff.DecisionRetryAfter(2 * time.Second),
+		},
+		{
+			name: "503 with Retry-After as an HTTP-date honors it",
+			err: &ResponseError{Response: response(http.StatusServiceUnavailable, http.Header{
+				"Retry-After": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)},
+			})},
+			want: backoff.DecisionRetry, // duration varies; checked separately below
+		},
+		{
+			name: "other 5xx retries",
+			err:  &ResponseError{Response: response(http.StatusBadGateway, nil)},
+			want: backoff.DecisionRetry,
+		},
+		{
+			name: "other 4xx stops",
+			err:  &ResponseError{Response: response(http.StatusNotFound, nil)},
+			want: backoff.DecisionStop,
+		},
+		{
+			name: "2xx retries",
+			err:  &ResponseError{Response: response(http.StatusOK, nil)},
+			want: backoff.DecisionRetry,
+		},
+		{
+			name: "url.Error wrapping a net.OpError retries",
+			err:  &url.Error{Op: "Get", URL: "http://example.com", Err: &net.OpError{Op: "dial"}},
+			want: backoff.DecisionRetry,
+		},
+		{
+			name: "timed-out url.Error retries",
+			err:  &url.Error{Op: "Get", URL: "http://example.com", Err: errTimeout{}},
+			want: backoff.DecisionRetry,
+		},
+		{
+			name: "non-network url.Error stops",
+			err:  &url.Error{Op: "Get", URL: "http://example.com", Err: errors.New("bad url")},
+			want: backoff.DecisionStop,
+		},
+		{
+			name: "unrecognized error retries",
+			err:  errors.New("boom"),
+			want: backoff.DecisionRetry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DefaultHTTPRetryPolicy(context.Background(), tt.err)
+			if tt.name == "503 with Retry-After as an HTTP-date honors it" {
+				if got == backoff.DecisionRetry || got == backoff.DecisionStop {
+					t.Fatalf("got %v, want a DecisionRetryAfter derived from the HTTP-date", got)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type errTimeout struct{}
+
+func (errTimeout) Error() string   { return "timeout" }
+func (errTimeout) Timeout() bool   { return true }
+func (errTimeout) Temporary() bool { return true }
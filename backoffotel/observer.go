@@ -0,0 +1,45 @@
+// Package backoffotel adapts backoff.Observer to OpenTelemetry tracing: each
+// retried attempt becomes a span event, and when the retry loop gives up, its
+// cause and attempt count are recorded as attributes on that same span. A
+// retry that ends in success sets no attributes.
+package backoffotel
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gosharmot/backoff"
+)
+
+// Observer is a backoff.Observer that records retry attempts as events on
+// Span, the span active in the context Retry/RetryCtx runs under.
+type Observer struct {
+	Span trace.Span
+}
+
+// New returns an Observer that records events and attributes on span.
+func New(span trace.Span) *Observer {
+	return &Observer{Span: span}
+}
+
+func (o *Observer) OnAttempt(info backoff.AttemptInfo) {
+	o.Span.AddEvent("retry.attempt", trace.WithAttributes(
+		attribute.Int64("retry.attempt", int64(info.Attempt)),
+		attribute.String("retry.error", info.Err.Error()),
+		attribute.String("retry.next_delay", info.Next.String()),
+	))
+}
+
+func (o *Observer) OnGiveUp(info backoff.AttemptInfo, err error) {
+	o.Span.AddEvent("retry.give_up", trace.WithAttributes(
+		attribute.Int64("retry.attempt", int64(info.Attempt)),
+		attribute.String("retry.cause", info.Cause.String()),
+	))
+	o.Span.SetAttributes(
+		attribute.Int64("retry.attempts", int64(info.Attempt)),
+		attribute.String("retry.cause", info.Cause.String()),
+	)
+	if err != nil {
+		o.Span.RecordError(err)
+	}
+}
@@ -0,0 +1,111 @@
+package backoffotel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/gosharmot/backoff"
+)
+
+func TestObserver(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := tp.Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "op")
+	obs := New(span)
+
+	errBoom := errors.New("boom")
+	calls := 0
+	_, err := backoff.Retry(ctx, func() (string, error) {
+		calls++
+		if calls < 2 {
+			return "", errBoom
+		}
+		return "ok", nil
+	}, backoff.WithTimer(newFakeTimer()), backoff.WithObserver(obs))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d recorded spans, want 1", len(spans))
+	}
+	events := spans[0].Events
+	if len(events) != 1 || events[0].Name != "retry.attempt" {
+		t.Fatalf("got events %+v, want a single retry.attempt event", events)
+	}
+
+	var gotError string
+	for _, a := range events[0].Attributes {
+		if string(a.Key) == "retry.error" {
+			gotError = a.Value.AsString()
+		}
+	}
+	if gotError != errBoom.Error() {
+		t.Errorf("got retry.error attribute %q, want %q", gotError, errBoom.Error())
+	}
+}
+
+func TestObserver_GiveUp(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := tp.Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "op")
+	obs := New(span)
+
+	errBoom := errors.New("boom")
+	_, err := backoff.Retry(ctx, func() (string, error) {
+		return "", backoff.Permanent(errBoom)
+	}, backoff.WithTimer(newFakeTimer()), backoff.WithObserver(obs))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d recorded spans, want 1", len(spans))
+	}
+
+	var gotCause string
+	for _, a := range spans[0].Attributes {
+		if string(a.Key) == "retry.cause" {
+			gotCause = a.Value.AsString()
+		}
+	}
+	if gotCause != backoff.StopCausePermanent.String() {
+		t.Errorf("got retry.cause attribute %q, want %q", gotCause, backoff.StopCausePermanent.String())
+	}
+	var sawGiveUp bool
+	for _, e := range spans[0].Events {
+		if e.Name == "retry.give_up" {
+			sawGiveUp = true
+		}
+	}
+	if !sawGiveUp {
+		t.Fatalf("got events %+v, want a retry.give_up event", spans[0].Events)
+	}
+}
+
+// fakeTimer fires immediately, mirroring the main package's test helper of
+// the same name, so these tests don't wait out real backoff delays.
+type fakeTimer struct {
+	c chan time.Time
+}
+
+func newFakeTimer() *fakeTimer {
+	return &fakeTimer{c: make(chan time.Time, 1)}
+}
+
+func (t *fakeTimer) Start(time.Duration) { t.c <- time.Now() }
+func (t *fakeTimer) Stop()               {}
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
@@ -0,0 +1,54 @@
+// Package backoffprom adapts backoff.Observer to Prometheus metrics:
+// retry_attempts_total, retry_give_up_total (labeled by cause), and a
+// retry_delay_seconds histogram, each labeled by a caller-supplied name for
+// the operation being retried.
+package backoffprom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gosharmot/backoff"
+)
+
+var (
+	attemptsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "retry_attempts_total",
+		Help: "Number of retry attempts that were followed by another attempt.",
+	}, []string{"name"})
+
+	giveUpTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "retry_give_up_total",
+		Help: "Number of times a retry loop gave up, by cause.",
+	}, []string{"name", "cause"})
+
+	delaySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "retry_delay_seconds",
+		Help:    "Delay before the next retry attempt.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"name"})
+)
+
+func init() {
+	prometheus.MustRegister(attemptsTotal, giveUpTotal, delaySeconds)
+}
+
+// Observer is a backoff.Observer that records attempts under Name against
+// the default Prometheus registry.
+type Observer struct {
+	Name string
+}
+
+// New returns an Observer that labels its metrics with name, e.g. the name
+// of the operation being retried.
+func New(name string) *Observer {
+	return &Observer{Name: name}
+}
+
+func (o *Observer) OnAttempt(info backoff.AttemptInfo) {
+	attemptsTotal.WithLabelValues(o.Name).Inc()
+	delaySeconds.WithLabelValues(o.Name).Observe(info.Next.Seconds())
+}
+
+func (o *Observer) OnGiveUp(info backoff.AttemptInfo, _ error) {
+	giveUpTotal.WithLabelValues(o.Name, info.Cause.String()).Inc()
+}
@@ -0,0 +1,76 @@
+package backoffprom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/gosharmot/backoff"
+)
+
+// fakeTimer fires immediately, mirroring the main package's test helper of
+// the same name, so this test doesn't wait out real backoff delays.
+type fakeTimer struct {
+	c chan time.Time
+}
+
+func newFakeTimer() *fakeTimer {
+	return &fakeTimer{c: make(chan time.Time, 1)}
+}
+
+func (t *fakeTimer) Start(time.Duration) { t.c <- time.Now() }
+func (t *fakeTimer) Stop()               {}
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+func TestObserver(t *testing.T) {
+	// Each test gets its own Name label so the shared, package-level
+	// metrics don't bleed counts between test runs.
+	obs := New(t.Name())
+
+	errBoom := errors.New("boom")
+	calls := 0
+	_, err := backoff.Retry(context.Background(), func() (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errBoom
+		}
+		return "ok", nil
+	}, backoff.WithTimer(newFakeTimer()), backoff.WithObserver(obs))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(attemptsTotal.WithLabelValues(t.Name())); got != 2 {
+		t.Errorf("got retry_attempts_total %v, want 2", got)
+	}
+
+	var m dto.Metric
+	if err := delaySeconds.WithLabelValues(t.Name()).(prometheus.Metric).Write(&m); err != nil {
+		t.Fatalf("writing retry_delay_seconds: %v", err)
+	}
+	if got := m.GetHistogram().GetSampleCount(); got != 2 {
+		t.Errorf("got retry_delay_seconds sample count %d, want 2", got)
+	}
+}
+
+func TestObserver_GiveUp(t *testing.T) {
+	obs := New(t.Name())
+
+	errBoom := errors.New("boom")
+	_, err := backoff.Retry(context.Background(), func() (string, error) {
+		return "", backoff.Permanent(errBoom)
+	}, backoff.WithTimer(newFakeTimer()), backoff.WithObserver(obs))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	want := backoff.StopCausePermanent.String()
+	if got := testutil.ToFloat64(giveUpTotal.WithLabelValues(t.Name(), want)); got != 1 {
+		t.Errorf("got retry_give_up_total{cause=%q} %v, want 1", want, got)
+	}
+}
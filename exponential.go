@@ -0,0 +1,70 @@
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	DefaultInitialInterval     = 500 * time.Millisecond
+	DefaultRandomizationFactor = 0.5
+	DefaultMultiplier          = 1.5
+	DefaultMaxInterval         = 60 * time.Second
+)
+
+// ExponentialBackOff is a BackOff that increases the delay for each attempt
+// exponentially, randomized by RandomizationFactor.
+type ExponentialBackOff struct {
+	InitialInterval     time.Duration
+	RandomizationFactor float64
+	Multiplier          float64
+	MaxInterval         time.Duration
+
+	currentInterval time.Duration
+}
+
+// NewExponentialBackOff creates an ExponentialBackOff with default values.
+func NewExponentialBackOff() *ExponentialBackOff {
+	b := &ExponentialBackOff{
+		InitialInterval:     DefaultInitialInterval,
+		RandomizationFactor: DefaultRandomizationFactor,
+		Multiplier:          DefaultMultiplier,
+		MaxInterval:         DefaultMaxInterval,
+	}
+	b.Reset()
+	return b
+}
+
+func (b *ExponentialBackOff) Reset() {
+	b.currentInterval = b.InitialInterval
+}
+
+// Clone returns an independent copy of b.
+func (b *ExponentialBackOff) Clone() (BackOff, bool) {
+	clone := *b
+	return &clone, true
+}
+
+func (b *ExponentialBackOff) NextBackOff() time.Duration {
+	next := randomizedInterval(b.currentInterval, b.RandomizationFactor)
+	b.incrementCurrentInterval()
+	return next
+}
+
+func (b *ExponentialBackOff) incrementCurrentInterval() {
+	if float64(b.currentInterval) >= float64(b.MaxInterval)/b.Multiplier {
+		b.currentInterval = b.MaxInterval
+		return
+	}
+	b.currentInterval = time.Duration(float64(b.currentInterval) * b.Multiplier)
+}
+
+func randomizedInterval(interval time.Duration, randomizationFactor float64) time.Duration {
+	if randomizationFactor == 0 {
+		return interval
+	}
+	delta := randomizationFactor * float64(interval)
+	min := float64(interval) - delta
+	max := float64(interval) + delta
+	return time.Duration(min + rand.Float64()*(max-min+1))
+}
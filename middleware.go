@@ -0,0 +1,214 @@
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// WithFullJitter wraps b so each delay is replaced with a uniformly random
+// duration in [0, next).
+func WithFullJitter(b BackOff) BackOff {
+	return &fullJitterBackOff{BackOff: b}
+}
+
+type fullJitterBackOff struct {
+	BackOff
+}
+
+func (b *fullJitterBackOff) NextBackOff() time.Duration {
+	return b.NextBackOffErr(nil)
+}
+
+// NextBackOffErr forwards err to the inner BackOff so a filterBackOff
+// stacked underneath still gets to see it and stop retries.
+func (b *fullJitterBackOff) NextBackOffErr(err error) time.Duration {
+	next := nextBackOff(b.BackOff, err)
+	if next == Stop || next <= 0 {
+		return next
+	}
+	return time.Duration(rand.Int63n(int64(next)))
+}
+
+func (b *fullJitterBackOff) Clone() (BackOff, bool) {
+	inner, ok := cloneBackOff(b.BackOff)
+	if !ok {
+		return nil, false
+	}
+	return &fullJitterBackOff{BackOff: inner}, true
+}
+
+// WithEqualJitter wraps b so each delay is replaced with a random duration
+// in [next/2, next).
+func WithEqualJitter(b BackOff) BackOff {
+	return &equalJitterBackOff{BackOff: b}
+}
+
+type equalJitterBackOff struct {
+	BackOff
+}
+
+func (b *equalJitterBackOff) NextBackOff() time.Duration {
+	return b.NextBackOffErr(nil)
+}
+
+// NextBackOffErr forwards err to the inner BackOff so a filterBackOff
+// stacked underneath still gets to see it and stop retries.
+func (b *equalJitterBackOff) NextBackOffErr(err error) time.Duration {
+	next := nextBackOff(b.BackOff, err)
+	if next == Stop || next <= 0 {
+		return next
+	}
+	half := next / 2
+	return half + time.Duration(rand.Int63n(int64(half)))
+}
+
+func (b *equalJitterBackOff) Clone() (BackOff, bool) {
+	inner, ok := cloneBackOff(b.BackOff)
+	if !ok {
+		return nil, false
+	}
+	return &equalJitterBackOff{BackOff: inner}, true
+}
+
+// WithCap wraps b so that it never reports a delay longer than max.
+func WithCap(max time.Duration, b BackOff) BackOff {
+	return &capBackOff{BackOff: b, max: max}
+}
+
+type capBackOff struct {
+	BackOff
+	max time.Duration
+}
+
+func (b *capBackOff) NextBackOff() time.Duration {
+	return b.NextBackOffErr(nil)
+}
+
+// NextBackOffErr forwards err to the inner BackOff so a filterBackOff
+// stacked underneath still gets to see it and stop retries.
+func (b *capBackOff) NextBackOffErr(err error) time.Duration {
+	next := nextBackOff(b.BackOff, err)
+	if next == Stop || next <= b.max {
+		return next
+	}
+	return b.max
+}
+
+func (b *capBackOff) Clone() (BackOff, bool) {
+	inner, ok := cloneBackOff(b.BackOff)
+	if !ok {
+		return nil, false
+	}
+	return &capBackOff{BackOff: inner, max: b.max}, true
+}
+
+// WithMaxRetries wraps b so that it stops after n delays have been handed
+// out, regardless of what b itself would otherwise return.
+func WithMaxRetries(n uint, b BackOff) BackOff {
+	return &maxRetriesBackOff{BackOff: b, max: n}
+}
+
+type maxRetriesBackOff struct {
+	BackOff
+	max   uint
+	tries uint
+}
+
+func (b *maxRetriesBackOff) NextBackOff() time.Duration {
+	return b.NextBackOffErr(nil)
+}
+
+// NextBackOffErr forwards err to the inner BackOff so a filterBackOff
+// stacked underneath still gets to see it and stop retries.
+func (b *maxRetriesBackOff) NextBackOffErr(err error) time.Duration {
+	if b.tries >= b.max {
+		return Stop
+	}
+	b.tries++
+	return nextBackOff(b.BackOff, err)
+}
+
+func (b *maxRetriesBackOff) Reset() {
+	b.tries = 0
+	b.BackOff.Reset()
+}
+
+func (b *maxRetriesBackOff) Clone() (BackOff, bool) {
+	inner, ok := cloneBackOff(b.BackOff)
+	if !ok {
+		return nil, false
+	}
+	return &maxRetriesBackOff{BackOff: inner, max: b.max}, true
+}
+
+// ErrBackOff is implemented by policies that need the failed attempt's error
+// to decide the next delay, such as the one returned by WithFilter.
+type ErrBackOff interface {
+	BackOff
+
+	NextBackOffErr(err error) time.Duration
+}
+
+// WithFilter wraps b so retrying stops as soon as filter rejects an error.
+func WithFilter(filter func(error) bool, b BackOff) BackOff {
+	return &filterBackOff{BackOff: b, filter: filter}
+}
+
+type filterBackOff struct {
+	BackOff
+	filter func(error) bool
+}
+
+// NextBackOff treats a nil err as passing the filter, since a caller going
+// through the plain BackOff interface has no error to offer.
+func (b *filterBackOff) NextBackOff() time.Duration {
+	return b.NextBackOffErr(nil)
+}
+
+func (b *filterBackOff) NextBackOffErr(err error) time.Duration {
+	if err != nil && !b.filter(err) {
+		return Stop
+	}
+	return nextBackOff(b.BackOff, err)
+}
+
+func (b *filterBackOff) Clone() (BackOff, bool) {
+	inner, ok := cloneBackOff(b.BackOff)
+	if !ok {
+		return nil, false
+	}
+	return &filterBackOff{BackOff: inner, filter: b.filter}, true
+}
+
+// NewFibonacci returns a BackOff whose delays grow along the Fibonacci
+// sequence scaled by base (base, base, 2*base, 3*base, 5*base, ...). Combine
+// it with WithCap to bound the maximum delay.
+func NewFibonacci(base time.Duration) *FibonacciBackOff {
+	b := &FibonacciBackOff{Base: base}
+	b.Reset()
+	return b
+}
+
+// FibonacciBackOff is a BackOff that advances along the Fibonacci sequence.
+type FibonacciBackOff struct {
+	Base time.Duration
+
+	prev, cur time.Duration
+}
+
+func (b *FibonacciBackOff) NextBackOff() time.Duration {
+	next := b.cur
+	b.prev, b.cur = b.cur, b.prev+b.cur
+	return next
+}
+
+func (b *FibonacciBackOff) Reset() {
+	b.prev = 0
+	b.cur = b.Base
+}
+
+// Clone returns an independent copy of b.
+func (b *FibonacciBackOff) Clone() (BackOff, bool) {
+	clone := *b
+	return &clone, true
+}
@@ -0,0 +1,105 @@
+package backoff
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// constBackOff always returns the same delay; it exists only to give the
+// middleware tests a predictable BackOff to wrap.
+type constBackOff time.Duration
+
+func (d constBackOff) NextBackOff() time.Duration { return time.Duration(d) }
+func (d constBackOff) Reset()                     {}
+
+func TestWithFullJitter(t *testing.T) {
+	jittered := WithFullJitter(constBackOff(100 * time.Millisecond))
+	for i := 0; i < 50; i++ {
+		next := jittered.NextBackOff()
+		if next < 0 || next >= 100*time.Millisecond {
+			t.Fatalf("got %v, want in [0, 100ms)", next)
+		}
+	}
+}
+
+func TestWithEqualJitter(t *testing.T) {
+	jittered := WithEqualJitter(constBackOff(100 * time.Millisecond))
+	for i := 0; i < 50; i++ {
+		next := jittered.NextBackOff()
+		if next < 50*time.Millisecond || next >= 100*time.Millisecond {
+			t.Fatalf("got %v, want in [50ms, 100ms)", next)
+		}
+	}
+}
+
+func TestWithCap(t *testing.T) {
+	capped := WithCap(10*time.Millisecond, constBackOff(100*time.Millisecond))
+	if next := capped.NextBackOff(); next != 10*time.Millisecond {
+		t.Fatalf("got %v, want 10ms", next)
+	}
+}
+
+func TestWithMaxRetries(t *testing.T) {
+	limited := WithMaxRetries(2, constBackOff(time.Millisecond))
+	for i := 0; i < 2; i++ {
+		if next := limited.NextBackOff(); next != time.Millisecond {
+			t.Fatalf("attempt %d: got %v, want 1ms", i, next)
+		}
+	}
+	if next := limited.NextBackOff(); next != Stop {
+		t.Fatalf("got %v, want Stop after max retries", next)
+	}
+}
+
+func TestWithFilter(t *testing.T) {
+	errBoom := errors.New("boom")
+	filtered := WithFilter(func(err error) bool {
+		return !errors.Is(err, errBoom)
+	}, constBackOff(time.Millisecond))
+
+	eb, ok := filtered.(ErrBackOff)
+	if !ok {
+		t.Fatal("WithFilter's result does not implement ErrBackOff")
+	}
+	if next := eb.NextBackOffErr(errors.New("other")); next != time.Millisecond {
+		t.Fatalf("got %v, want 1ms for an accepted error", next)
+	}
+	if next := eb.NextBackOffErr(errBoom); next != Stop {
+		t.Fatalf("got %v, want Stop for a rejected error", next)
+	}
+}
+
+// TestWithFilter_UnderOtherMiddleware checks that stacking WithCap or
+// WithFullJitter on top of WithFilter doesn't lose the filter's Stop
+// decision: nextBackOff must reach filterBackOff.NextBackOffErr through
+// any number of wrapping layers.
+func TestWithFilter_UnderOtherMiddleware(t *testing.T) {
+	errBoom := errors.New("boom")
+	rejectBoom := func(err error) bool { return !errors.Is(err, errBoom) }
+
+	capped := WithCap(time.Minute, WithFilter(rejectBoom, constBackOff(time.Millisecond)))
+	if next := nextBackOff(capped, errBoom); next != Stop {
+		t.Errorf("WithCap(WithFilter(...)): got %v, want Stop for a rejected error", next)
+	}
+
+	jittered := WithFullJitter(WithFilter(rejectBoom, constBackOff(time.Millisecond)))
+	if next := nextBackOff(jittered, errBoom); next != Stop {
+		t.Errorf("WithFullJitter(WithFilter(...)): got %v, want Stop for a rejected error", next)
+	}
+
+	limited := WithMaxRetries(5, WithFilter(rejectBoom, constBackOff(time.Millisecond)))
+	if next := nextBackOff(limited, errBoom); next != Stop {
+		t.Errorf("WithMaxRetries(WithFilter(...)): got %v, want Stop for a rejected error", next)
+	}
+}
+
+func TestNewFibonacci(t *testing.T) {
+	fib := NewFibonacci(time.Millisecond)
+	want := []time.Duration{1, 1, 2, 3, 5, 8}
+	for i, w := range want {
+		if next := fib.NextBackOff(); next != w*time.Millisecond {
+			t.Fatalf("step %d: got %v, want %v", i, next, w*time.Millisecond)
+		}
+	}
+}
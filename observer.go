@@ -0,0 +1,70 @@
+package backoff
+
+import "time"
+
+// StopCause identifies why Retry or RetryCtx gave up.
+type StopCause uint8
+
+const (
+	// StopCauseBackOff means the configured BackOff returned Stop.
+	StopCauseBackOff StopCause = iota
+	// StopCausePolicy means a RetryPolicy returned DecisionStop.
+	StopCausePolicy
+	// StopCausePermanent means the operation returned a *PermanentError.
+	StopCausePermanent
+	// StopCauseMaxTries means WithMaxTries was reached.
+	StopCauseMaxTries
+	// StopCauseMaxElapsedTime means WithMaxElapsedTime (or
+	// DefaultMaxElapsedTime) was exceeded.
+	StopCauseMaxElapsedTime
+	// StopCauseContextCanceled means ctx was done before or during the wait
+	// for the next attempt.
+	StopCauseContextCanceled
+)
+
+func (c StopCause) String() string {
+	switch c {
+	case StopCausePolicy:
+		return "policy"
+	case StopCausePermanent:
+		return "permanent"
+	case StopCauseMaxTries:
+		return "max-tries"
+	case StopCauseMaxElapsedTime:
+		return "max-elapsed"
+	case StopCauseContextCanceled:
+		return "context-canceled"
+	default:
+		return "backoff-stop"
+	}
+}
+
+// AttemptInfo describes a single attempt made by Retry or RetryCtx.
+type AttemptInfo struct {
+	// Attempt is the 1-based number of the attempt this info describes.
+	Attempt uint
+	// Elapsed is the time since Retry/RetryCtx started.
+	Elapsed time.Duration
+	// Err is the error the attempt returned.
+	Err error
+	// Next is the delay before the next attempt. It is zero once giving up.
+	Next time.Duration
+	// Cause is why Retry gave up. It is only meaningful on OnGiveUp.
+	Cause StopCause
+}
+
+// Observer is a structured alternative to Notify for integrating metrics
+// and tracing: OnAttempt is called once per retried (non-final) attempt,
+// and OnGiveUp once when Retry/RetryCtx stops for good, carrying why.
+type Observer interface {
+	OnAttempt(AttemptInfo)
+	OnGiveUp(AttemptInfo, error)
+}
+
+// WithObserver sets the Observer notified about retry attempts, in addition
+// to any Notify set with WithNotify.
+func WithObserver(o Observer) RetryOption {
+	return func(args *retryOptions) {
+		args.Observer = o
+	}
+}
@@ -0,0 +1,130 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type recordingObserver struct {
+	attempts []AttemptInfo
+	giveUps  []AttemptInfo
+	giveUp   error
+}
+
+func (o *recordingObserver) OnAttempt(info AttemptInfo) {
+	o.attempts = append(o.attempts, info)
+}
+
+func (o *recordingObserver) OnGiveUp(info AttemptInfo, err error) {
+	o.giveUps = append(o.giveUps, info)
+	o.giveUp = err
+}
+
+func TestObserver_OnAttempt(t *testing.T) {
+	obs := &recordingObserver{}
+	errBoom := errors.New("boom")
+	calls := 0
+
+	Retry(context.Background(), func() (string, error) {
+		calls++
+		if calls < 2 {
+			return "", errBoom
+		}
+		return "ok", nil
+	}, WithTimer(newFakeTimer()), WithObserver(obs))
+
+	if len(obs.attempts) != 1 {
+		t.Fatalf("got %d OnAttempt calls, want 1", len(obs.attempts))
+	}
+	if obs.attempts[0].Attempt != 1 || !errors.Is(obs.attempts[0].Err, errBoom) {
+		t.Errorf("got %+v, want attempt 1 with err %v", obs.attempts[0], errBoom)
+	}
+	if len(obs.giveUps) != 0 {
+		t.Errorf("got %d OnGiveUp calls, want 0 on success", len(obs.giveUps))
+	}
+}
+
+func TestObserver_OnGiveUp_StopCauses(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name string
+		opts []RetryOption
+		want StopCause
+	}{
+		{
+			name: "BackOff stop",
+			opts: []RetryOption{WithBackOff(&fakeBackOff{}), WithTimer(newFakeTimer())},
+			want: StopCauseBackOff,
+		},
+		{
+			name: "RetryPolicy stop",
+			opts: []RetryOption{WithTimer(newFakeTimer()), WithRetryPolicy(func(context.Context, error) Decision {
+				return DecisionStop
+			})},
+			want: StopCausePolicy,
+		},
+		{
+			name: "MaxTries",
+			opts: []RetryOption{WithTimer(newFakeTimer()), WithMaxTries(1)},
+			want: StopCauseMaxTries,
+		},
+		{
+			name: "MaxElapsedTime",
+			opts: []RetryOption{WithTimer(newFakeTimer()), WithMaxElapsedTime(0)},
+			want: StopCauseMaxElapsedTime,
+		},
+		{
+			name: "Permanent",
+			opts: []RetryOption{WithTimer(newFakeTimer())},
+			want: StopCausePermanent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obs := &recordingObserver{}
+			opts := append(append([]RetryOption{}, tt.opts...), WithObserver(obs))
+
+			operation := func() (string, error) { return "", errBoom }
+			if tt.name == "Permanent" {
+				operation = func() (string, error) { return "", Permanent(errBoom) }
+			}
+
+			_, err := Retry(context.Background(), operation, opts...)
+
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if len(obs.giveUps) != 1 {
+				t.Fatalf("got %d OnGiveUp calls, want 1", len(obs.giveUps))
+			}
+			if obs.giveUps[0].Cause != tt.want {
+				t.Errorf("got cause %v, want %v", obs.giveUps[0].Cause, tt.want)
+			}
+			if !errors.Is(obs.giveUp, err) {
+				t.Errorf("OnGiveUp's err %v does not match Retry's returned err %v", obs.giveUp, err)
+			}
+		})
+	}
+}
+
+func TestStopCause_String(t *testing.T) {
+	tests := []struct {
+		cause StopCause
+		want  string
+	}{
+		{StopCauseBackOff, "backoff-stop"},
+		{StopCausePolicy, "policy"},
+		{StopCausePermanent, "permanent"},
+		{StopCauseMaxTries, "max-tries"},
+		{StopCauseMaxElapsedTime, "max-elapsed"},
+		{StopCauseContextCanceled, "context-canceled"},
+	}
+	for _, tt := range tests {
+		if got := tt.cause.String(); got != tt.want {
+			t.Errorf("%d.String() = %q, want %q", tt.cause, got, tt.want)
+		}
+	}
+}
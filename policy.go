@@ -0,0 +1,50 @@
+package backoff
+
+import (
+	"context"
+	"time"
+)
+
+// decisionKind is the classification made by a RetryPolicy.
+type decisionKind uint8
+
+const (
+	decisionRetry decisionKind = iota
+	decisionStop
+	decisionRetryAfter
+)
+
+// Decision is what a RetryPolicy returns after classifying an error.
+type Decision struct {
+	kind  decisionKind
+	after time.Duration
+}
+
+// DecisionRetry continues retrying on the configured BackOff's normal
+// schedule.
+var DecisionRetry = Decision{kind: decisionRetry}
+
+// DecisionStop stops retrying immediately, as if the operation had returned
+// a PermanentError.
+var DecisionStop = Decision{kind: decisionStop}
+
+// DecisionRetryAfter waits d before the next attempt instead of consulting
+// the BackOff, and resets it, exactly as returning a RetryAfterError from
+// the operation would.
+func DecisionRetryAfter(d time.Duration) Decision {
+	return Decision{kind: decisionRetryAfter, after: d}
+}
+
+// RetryPolicy classifies an operation's error to decide whether Retry should
+// keep going, stop, or wait a specific duration, without the operation
+// itself having to know about backoff.
+type RetryPolicy func(ctx context.Context, err error) Decision
+
+// WithRetryPolicy sets the RetryPolicy consulted, before the BackOff is asked
+// for the next delay, for an error that is neither a *PermanentError nor a
+// *RetryAfterError, once MaxTries and MaxElapsedTime still allow a retry.
+func WithRetryPolicy(p RetryPolicy) RetryOption {
+	return func(args *retryOptions) {
+		args.RetryPolicy = p
+	}
+}
@@ -0,0 +1,61 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRetry_RetryPolicyStop(t *testing.T) {
+	calls := 0
+
+	_, err := Retry(context.Background(), func() (string, error) {
+		calls++
+		return "", errors.New("boom")
+	}, WithTimer(newFakeTimer()), WithRetryPolicy(func(context.Context, error) Decision {
+		return DecisionStop
+	}))
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+}
+
+func TestRetry_MaxTriesWinsOverRetryPolicy(t *testing.T) {
+	calls := 0
+
+	_, err := Retry(context.Background(), func() (string, error) {
+		calls++
+		return "", errors.New("boom")
+	}, WithTimer(newFakeTimer()), WithMaxTries(3), WithRetryPolicy(func(context.Context, error) Decision {
+		return DecisionRetry
+	}))
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 3 {
+		t.Fatalf("got %d calls, want 3", calls)
+	}
+}
+
+func TestRetry_MaxElapsedTimeWinsOverRetryPolicy(t *testing.T) {
+	calls := 0
+
+	_, err := Retry(context.Background(), func() (string, error) {
+		calls++
+		return "", errors.New("boom")
+	}, WithTimer(newFakeTimer()), WithMaxElapsedTime(0), WithRetryPolicy(func(context.Context, error) Decision {
+		return DecisionRetry
+	}))
+
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+}
@@ -12,6 +12,11 @@ const DefaultMaxElapsedTime = 15 * time.Minute
 // An Operation is a function that is to be retried.
 type Operation[T any] func() (T, error)
 
+// An OperationCtx is a context-aware Operation. Use it with RetryCtx when
+// WithTryTimeout should bound each individual attempt rather than just the
+// overall retry budget.
+type OperationCtx[T any] func(ctx context.Context) (T, error)
+
 // Notify is a notify-on-error function. It receives an operation error and
 // backoff delay if the operation failed (with an error).
 //
@@ -23,8 +28,13 @@ type retryOptions struct {
 	BackOff        BackOff
 	Timer          Timer
 	Notify         Notify
+	RetryPolicy    RetryPolicy
 	MaxElapsedTime time.Duration
 	MaxTries       uint
+	TryTimeout     time.Duration
+	Concurrency    int
+	GroupNotify    any
+	Observer       Observer
 }
 
 type RetryOption func(*retryOptions)
@@ -60,6 +70,19 @@ func WithMaxTries(n uint) RetryOption {
 	}
 }
 
+// WithTryTimeout bounds each individual attempt to d instead of just the
+// overall MaxElapsedTime. It requires RetryCtx; using it with Retry returns
+// ErrTryTimeoutRequiresRetryCtx.
+func WithTryTimeout(d time.Duration) RetryOption {
+	return func(args *retryOptions) {
+		args.TryTimeout = d
+	}
+}
+
+// ErrTryTimeoutRequiresRetryCtx is returned by Retry when WithTryTimeout is
+// set, since Operation has no context to bound.
+var ErrTryTimeoutRequiresRetryCtx = errors.New("backoff: WithTryTimeout requires RetryCtx")
+
 // Retry the operation o until it does not return error or BackOff stops.
 // o is guaranteed to be run at least once.
 //
@@ -69,7 +92,24 @@ func WithMaxTries(n uint) RetryOption {
 // Retry sleeps the goroutine for the duration returned by BackOff after a
 // failed operation returns.
 func Retry[T any](ctx context.Context, operation Operation[T], opts ...RetryOption) (T, error) {
-	// Default options
+	args := newRetryOptions(opts)
+	if args.TryTimeout > 0 {
+		var zero T
+		return zero, ErrTryTimeoutRequiresRetryCtx
+	}
+
+	return retry(ctx, func(context.Context) (T, error) {
+		return operation()
+	}, args)
+}
+
+// RetryCtx is Retry for an OperationCtx, the context-aware Operation
+// variant required by WithTryTimeout.
+func RetryCtx[T any](ctx context.Context, operation OperationCtx[T], opts ...RetryOption) (T, error) {
+	return retry(ctx, operation, newRetryOptions(opts))
+}
+
+func newRetryOptions(opts []RetryOption) *retryOptions {
 	args := &retryOptions{
 		BackOff:        NewExponentialBackOff(),
 		Timer:          &defaultTimer{},
@@ -80,47 +120,118 @@ func Retry[T any](ctx context.Context, operation Operation[T], opts ...RetryOpti
 		opt(args)
 	}
 
+	return args
+}
+
+func retry[T any](ctx context.Context, operation OperationCtx[T], args *retryOptions) (T, error) {
 	defer args.Timer.Stop()
 
 	startedAt := time.Now()
 	args.BackOff.Reset()
+
+	giveUp := func(numTries uint, res T, attemptErr, err error, cause StopCause) (T, error) {
+		if args.Observer != nil {
+			args.Observer.OnGiveUp(AttemptInfo{
+				Attempt: numTries,
+				Elapsed: time.Since(startedAt),
+				Err:     attemptErr,
+				Cause:   cause,
+			}, err)
+		}
+		return res, err
+	}
+
 	for numTries := uint(1); ; numTries++ {
-		res, err := operation()
+		res, err := callWithTryTimeout(ctx, args.TryTimeout, operation)
 		if err == nil {
 			return res, nil
 		}
 
 		if args.MaxTries > 0 && numTries >= args.MaxTries {
-			return res, err
+			return giveUp(numTries, res, err, err, StopCauseMaxTries)
 		}
 
 		if time.Since(startedAt) > args.MaxElapsedTime {
-			return res, err
+			return giveUp(numTries, res, err, err, StopCauseMaxElapsedTime)
 		}
 
 		var permanent *PermanentError
 		if errors.As(err, &permanent) {
-			return res, err
+			return giveUp(numTries, res, err, err, StopCausePermanent)
 		}
 
-		next := args.BackOff.NextBackOff()
-		if next == Stop {
-			return res, err
+		var next time.Duration
+		var retryAfter *RetryAfterError
+		switch {
+		case errors.As(err, &retryAfter):
+			// The server told us exactly how long to wait, so honor that
+			// instead of the scheduled backoff and let the policy start
+			// fresh on the next ordinary failure.
+			next = retryAfter.Duration
+			args.BackOff.Reset()
+		case args.RetryPolicy != nil:
+			switch decision := args.RetryPolicy(ctx, err); decision.kind {
+			case decisionStop:
+				return giveUp(numTries, res, err, err, StopCausePolicy)
+			case decisionRetryAfter:
+				next = decision.after
+				args.BackOff.Reset()
+			default: // decisionRetry defers to the configured BackOff
+				next = nextBackOff(args.BackOff, err)
+				if next == Stop {
+					return giveUp(numTries, res, err, err, StopCauseBackOff)
+				}
+			}
+		default:
+			next = nextBackOff(args.BackOff, err)
+			if next == Stop {
+				return giveUp(numTries, res, err, err, StopCauseBackOff)
+			}
 		}
 
 		if cerr := ctx.Err(); cerr != nil {
-			return res, cerr
+			return giveUp(numTries, res, err, cerr, StopCauseContextCanceled)
 		}
 
 		if args.Notify != nil {
 			args.Notify(err, next)
 		}
 
+		if args.Observer != nil {
+			args.Observer.OnAttempt(AttemptInfo{
+				Attempt: numTries,
+				Elapsed: time.Since(startedAt),
+				Err:     err,
+				Next:    next,
+			})
+		}
+
 		args.Timer.Start(next)
 		select {
 		case <-args.Timer.C():
 		case <-ctx.Done():
-			return res, ctx.Err()
+			return giveUp(numTries, res, err, ctx.Err(), StopCauseContextCanceled)
 		}
 	}
 }
+
+// callWithTryTimeout runs operation under a context.WithTimeout derived from
+// ctx when timeout is set, so a single hung attempt can't outlast it.
+func callWithTryTimeout[T any](ctx context.Context, timeout time.Duration, operation OperationCtx[T]) (T, error) {
+	if timeout <= 0 {
+		return operation(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return operation(attemptCtx)
+}
+
+// nextBackOff asks b for the next delay, passing err along when b
+// implements ErrBackOff (e.g. the result of WithFilter).
+func nextBackOff(b BackOff, err error) time.Duration {
+	if eb, ok := b.(ErrBackOff); ok {
+		return eb.NextBackOffErr(err)
+	}
+	return b.NextBackOff()
+}
@@ -0,0 +1,73 @@
+package backoff
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeTimer fires immediately regardless of the requested duration, so
+// tests don't have to wait out real backoff delays.
+type fakeTimer struct {
+	c chan time.Time
+}
+
+func newFakeTimer() *fakeTimer {
+	return &fakeTimer{c: make(chan time.Time, 1)}
+}
+
+func (t *fakeTimer) Start(time.Duration) { t.c <- time.Now() }
+func (t *fakeTimer) Stop()               {}
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+// fakeBackOff lets tests assert how many times NextBackOff/Reset were
+// called, instead of depending on ExponentialBackOff's real schedule.
+type fakeBackOff struct {
+	next      []time.Duration
+	nextCalls int
+	resets    int
+}
+
+func (b *fakeBackOff) NextBackOff() time.Duration {
+	if b.nextCalls >= len(b.next) {
+		return Stop
+	}
+	d := b.next[b.nextCalls]
+	b.nextCalls++
+	return d
+}
+
+func (b *fakeBackOff) Reset() { b.resets++ }
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	bo := &fakeBackOff{next: []time.Duration{time.Hour}}
+	var notified []time.Duration
+	calls := 0
+
+	_, err := Retry(context.Background(), func() (string, error) {
+		calls++
+		if calls == 1 {
+			return "", RetryAfter(0)
+		}
+		return "ok", nil
+	}, WithBackOff(bo), WithTimer(newFakeTimer()), WithNotify(func(_ error, next time.Duration) {
+		notified = append(notified, next)
+	}))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("got %d calls, want 2", calls)
+	}
+	// 1 initial Reset from retry()'s setup, plus 1 from honoring RetryAfter.
+	if bo.resets != 2 {
+		t.Errorf("got %d BackOff resets, want 2", bo.resets)
+	}
+	if bo.nextCalls != 0 {
+		t.Errorf("NextBackOff was consulted %d times, want 0 when RetryAfterError is present", bo.nextCalls)
+	}
+	if len(notified) != 1 || notified[0] != 0 {
+		t.Errorf("got notified delays %v, want [0]", notified)
+	}
+}
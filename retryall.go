@@ -0,0 +1,91 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBackOffNotCloneable is returned by RetryAll when the configured BackOff
+// doesn't implement BackOffCloner, so it can't be cloned per item. RetryAll
+// refuses to run rather than silently give every item a fresh
+// NewExponentialBackOff instead of the policy the caller asked for.
+var ErrBackOffNotCloneable = errors.New("backoff: BackOff does not implement BackOffCloner")
+
+// WithConcurrency caps the number of goroutines RetryAll runs at once. It has
+// no effect on Retry or RetryCtx. The default is one goroutine per item.
+func WithConcurrency(n int) RetryOption {
+	return func(args *retryOptions) {
+		args.Concurrency = n
+	}
+}
+
+// WithGroupNotify sets a per-key Notify for RetryAll, reporting the error
+// and delay for key's most recent attempt. It has no effect on Retry or
+// RetryCtx. If opts also includes WithNotify, WithGroupNotify takes
+// precedence for that item.
+func WithGroupNotify[K comparable](n func(key K, err error, next time.Duration)) RetryOption {
+	return func(args *retryOptions) {
+		args.GroupNotify = n
+	}
+}
+
+// RetryAll retries op for every key in items concurrently, each with its own
+// independent BackOff and elapsed-time budget, and returns the successful
+// results and terminal errors keyed by item. The returned error is non-nil
+// only if the configured BackOff can't be cloned per item (ErrBackOffNotCloneable);
+// check the returned error map, not this one, to see which keys failed.
+func RetryAll[K comparable, T any](ctx context.Context, items []K, op func(context.Context, K) (T, error), opts ...RetryOption) (map[K]T, map[K]error, error) {
+	args := newRetryOptions(opts)
+	if _, ok := cloneBackOff(args.BackOff); !ok {
+		return nil, nil, ErrBackOffNotCloneable
+	}
+	groupNotify, _ := args.GroupNotify.(func(K, error, time.Duration))
+
+	concurrency := args.Concurrency
+	if concurrency <= 0 {
+		concurrency = len(items)
+	}
+
+	results := make(map[K]T, len(items))
+	errs := make(map[K]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for _, key := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key K) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			// Each item gets its own BackOff clone so concurrent goroutines
+			// never share mutable backoff state. args.BackOff's cloneability
+			// was already checked above.
+			clone, _ := cloneBackOff(args.BackOff)
+			itemOpts := append(append([]RetryOption{}, opts...), WithBackOff(clone))
+			if groupNotify != nil {
+				itemOpts = append(itemOpts, WithNotify(func(err error, next time.Duration) {
+					groupNotify(key, err, next)
+				}))
+			}
+
+			res, err := RetryCtx(ctx, func(ctx context.Context) (T, error) {
+				return op(ctx, key)
+			}, itemOpts...)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[key] = err
+			} else {
+				results[key] = res
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	return results, errs, nil
+}
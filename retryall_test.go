@@ -0,0 +1,172 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCloneBackOff_Independent(t *testing.T) {
+	b := NewExponentialBackOff()
+	b.NextBackOff()
+
+	cloned, ok := cloneBackOff(b)
+	if !ok {
+		t.Fatal("cloneBackOff reported b as not cloneable")
+	}
+	clone, ok := cloned.(*ExponentialBackOff)
+	if !ok {
+		t.Fatal("clone is not an *ExponentialBackOff")
+	}
+	if clone == b {
+		t.Fatal("cloneBackOff returned the same pointer")
+	}
+
+	clone.currentInterval = time.Hour
+	if b.currentInterval == time.Hour {
+		t.Fatal("mutating the clone affected the original")
+	}
+}
+
+type noCloneBackOff struct{ constBackOff }
+
+func TestCloneBackOff_NotCloneable(t *testing.T) {
+	if _, ok := cloneBackOff(noCloneBackOff{}); ok {
+		t.Fatal("cloneBackOff reported a non-BackOffCloner as cloneable")
+	}
+}
+
+// TestRetryAll_BackOffNotCloneable checks that RetryAll refuses to run
+// rather than silently substitute a fresh NewExponentialBackOff for a
+// caller's BackOff that can't be cloned.
+func TestRetryAll_BackOffNotCloneable(t *testing.T) {
+	_, _, err := RetryAll(context.Background(), []int{1}, func(context.Context, int) (int, error) {
+		return 0, nil
+	}, WithBackOff(noCloneBackOff{}))
+
+	if !errors.Is(err, ErrBackOffNotCloneable) {
+		t.Fatalf("got %v, want ErrBackOffNotCloneable", err)
+	}
+}
+
+// TestRetryAll_ConcurrentIndependentBackOff exercises RetryAll with a
+// BackOff shared across all items under -race: before RetryAll cloned the
+// BackOff per item, concurrent goroutines raced on its mutable state.
+func TestRetryAll_ConcurrentIndependentBackOff(t *testing.T) {
+	shared := NewExponentialBackOff()
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	results, errs, err := RetryAll(context.Background(), items, func(_ context.Context, k int) (int, error) {
+		if k%2 == 0 {
+			return 0, errors.New("boom")
+		}
+		return k * 10, nil
+	}, WithBackOff(shared), WithTimer(newFakeTimer()), WithMaxTries(2))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results)+len(errs) != len(items) {
+		t.Fatalf("got %d results and %d errors, want %d total", len(results), len(errs), len(items))
+	}
+	for _, k := range items {
+		if k%2 == 0 {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("key %d: expected an error", k)
+			}
+		} else if got, ok := results[k]; !ok || got != k*10 {
+			t.Errorf("key %d: got result %d, ok=%v, want %d", k, got, ok, k*10)
+		}
+	}
+}
+
+func TestRetryAll_GroupNotify(t *testing.T) {
+	items := []string{"a", "b", "c"}
+
+	var callMu sync.Mutex
+	calls := map[string]int{}
+
+	var notifyMu sync.Mutex
+	notified := map[string]int{}
+
+	_, _, err := RetryAll(context.Background(), items, func(_ context.Context, k string) (string, error) {
+		callMu.Lock()
+		calls[k]++
+		n := calls[k]
+		callMu.Unlock()
+		if n < 2 {
+			return "", errors.New("boom")
+		}
+		return k, nil
+	}, WithTimer(newFakeTimer()), WithGroupNotify(func(key string, _ error, _ time.Duration) {
+		notifyMu.Lock()
+		notified[key]++
+		notifyMu.Unlock()
+	}))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	notifyMu.Lock()
+	defer notifyMu.Unlock()
+	for _, k := range items {
+		if notified[k] != 1 {
+			t.Errorf("key %s: got %d notifications, want 1", k, notified[k])
+		}
+	}
+}
+
+// TestRetryAll_GroupNotifyClonesBackOff guards against WithGroupNotify
+// clobbering the per-item BackOff clone: combining the two must still give
+// each item its own independent BackOff.
+func TestRetryAll_GroupNotifyClonesBackOff(t *testing.T) {
+	shared := NewExponentialBackOff()
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	_, _, err := RetryAll(context.Background(), items, func(_ context.Context, k int) (int, error) {
+		return 0, errors.New("boom")
+	}, WithBackOff(shared), WithTimer(newFakeTimer()), WithMaxTries(2),
+		WithGroupNotify(func(int, error, time.Duration) {}))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if shared.currentInterval != shared.InitialInterval {
+		t.Fatalf("shared BackOff was mutated: got currentInterval %v, want %v (WithGroupNotify must not drop the per-item clone)", shared.currentInterval, shared.InitialInterval)
+	}
+}
+
+func TestRetryAll_Concurrency(t *testing.T) {
+	const limit = 3
+
+	var active int32
+	var maxActive int32
+
+	items := make([]int, 20)
+	for i := range items {
+		items[i] = i
+	}
+
+	_, _, err := RetryAll(context.Background(), items, func(_ context.Context, k int) (int, error) {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			old := atomic.LoadInt32(&maxActive)
+			if n <= old || atomic.CompareAndSwapInt32(&maxActive, old, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		return k, nil
+	}, WithConcurrency(limit))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxActive > limit {
+		t.Fatalf("got max concurrency %d, want <= %d", maxActive, limit)
+	}
+}
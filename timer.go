@@ -0,0 +1,33 @@
+package backoff
+
+import "time"
+
+// Timer abstracts the standard library's time.Timer so tests can substitute
+// a fake implementation.
+type Timer interface {
+	Start(duration time.Duration)
+	Stop()
+	C() <-chan time.Time
+}
+
+type defaultTimer struct {
+	timer *time.Timer
+}
+
+func (t *defaultTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *defaultTimer) Start(duration time.Duration) {
+	if t.timer == nil {
+		t.timer = time.NewTimer(duration)
+		return
+	}
+	t.timer.Reset(duration)
+}
+
+func (t *defaultTimer) Stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
@@ -0,0 +1,45 @@
+package backoff
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetry_TryTimeoutRequiresRetryCtx(t *testing.T) {
+	_, err := Retry(context.Background(), func() (string, error) {
+		return "ok", nil
+	}, WithTryTimeout(time.Second))
+
+	if !errors.Is(err, ErrTryTimeoutRequiresRetryCtx) {
+		t.Fatalf("got %v, want ErrTryTimeoutRequiresRetryCtx", err)
+	}
+}
+
+func TestRetryCtx_TryTimeoutBoundsEachAttempt(t *testing.T) {
+	calls := 0
+	var sawDeadline []bool
+
+	_, err := RetryCtx(context.Background(), func(ctx context.Context) (string, error) {
+		calls++
+		_, ok := ctx.Deadline()
+		sawDeadline = append(sawDeadline, ok)
+		if calls < 2 {
+			return "", errors.New("boom")
+		}
+		return "ok", nil
+	}, WithTryTimeout(10*time.Millisecond), WithTimer(newFakeTimer()))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("got %d calls, want 2", calls)
+	}
+	for i, hadDeadline := range sawDeadline {
+		if !hadDeadline {
+			t.Errorf("attempt %d: expected a deadline from WithTryTimeout", i)
+		}
+	}
+}